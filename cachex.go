@@ -1,28 +1,83 @@
 package cachex
 
 import (
+	"container/list"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// Cache is a generic, in-memory, thread-safe cache with TTL support.
+const (
+	// NoExpiration marks an entry as never expiring, regardless of the cache's default TTL.
+	NoExpiration time.Duration = -1
+	// DefaultExpiration tells Set-like methods to fall back to the cache's default TTL.
+	DefaultExpiration time.Duration = 0
+)
+
+// expirationFor resolves a per-call ttl (possibly DefaultExpiration or NoExpiration)
+// against the cache's default TTL and returns an absolute expiration in UnixNano,
+// or 0 if the entry should never expire.
+func expirationFor(ttl, defaultTTL time.Duration) int64 {
+	if ttl == DefaultExpiration {
+		ttl = defaultTTL
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixNano()
+}
+
+// entry is the payload stored in the LRU list; items maps a key to its *list.Element
+// so Get/Set can reorder it in O(1).
+type entry[K comparable, V any] struct {
+	key  K
+	item Item[V]
+}
+
+// Cache is a generic, in-memory, thread-safe cache with TTL support. Entries are kept
+// in a doubly-linked list ordered by recency of use so that, when capacity > 0, the
+// least-recently-used entry can be evicted in O(1).
 type Cache[K comparable, V any] struct {
-	items   map[K]Item[V]
-	mu      sync.RWMutex
-	ttl     time.Duration
-	janitor *janitor
-	count   int64 // <- new: live counter
+	items     map[K]*list.Element
+	ll        *list.List
+	mu        sync.RWMutex
+	ttl       time.Duration
+	capacity  int // 0 means unbounded
+	janitor   *janitor
+	count     int64 // <- new: live counter
+	onEvicted func(K, V, EvictionReason)
+	inflight  map[K]*call[V]
+
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+	sets        uint64
+	deletes     uint64
 }
 
 // New creates a new cache instance with given default TTL and cleanup interval.
 func New[K comparable, V any](defaultTTL, cleanupInterval time.Duration) *Cache[K, V] {
+	return newCache[K, V](defaultTTL, cleanupInterval, 0)
+}
+
+// NewWithCapacity creates a cache bounded to maxItems entries. Once full, Set evicts
+// the least-recently-used entry to make room, calling Close() on its value if it
+// implements Closable.
+func NewWithCapacity[K comparable, V any](maxItems int, defaultTTL, cleanupInterval time.Duration) *Cache[K, V] {
+	return newCache[K, V](defaultTTL, cleanupInterval, maxItems)
+}
+
+func newCache[K comparable, V any](defaultTTL, cleanupInterval time.Duration, capacity int) *Cache[K, V] {
 	c := &Cache[K, V]{
-		items: make(map[K]Item[V]),
-		ttl:   defaultTTL,
+		items:    make(map[K]*list.Element),
+		ll:       list.New(),
+		ttl:      defaultTTL,
+		capacity: capacity,
 	}
-	// only start janitor if TTL is > 0
-	if defaultTTL > 0 && cleanupInterval > 0 {
+	// start the janitor whenever an interval is given: even without a default TTL or a
+	// capacity, entries stored via SetWithTTL can still expire and need sweeping
+	if cleanupInterval > 0 {
 		j := newJanitor(cleanupInterval)
 		c.janitor = j
 		j.run(c)
@@ -30,51 +85,214 @@ func New[K comparable, V any](defaultTTL, cleanupInterval time.Duration) *Cache[
 	return c
 }
 
+// OnEvicted registers fn to be called whenever an entry leaves the cache, reporting why.
+// fn is invoked outside the cache's lock, so it may safely call back into the cache.
+func (c *Cache[K, V]) OnEvicted(fn func(K, V, EvictionReason)) {
+	c.mu.Lock()
+	c.onEvicted = fn
+	c.mu.Unlock()
+}
+
 // Set stores a key-value pair and resets its expiration based on default TTL.
 func (c *Cache[K, V]) Set(key K, value V) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	evicted := c.setLocked(key, value, expirationFor(DefaultExpiration, c.ttl))
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
+}
+
+// SetWithTTL stores a key-value pair with a per-entry TTL that overrides the cache's
+// default. Pass NoExpiration for an entry that should never expire, or DefaultExpiration
+// to fall back to the cache's default TTL.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	evicted := c.setLocked(key, value, expirationFor(ttl, c.ttl))
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
 
-	_, exists := c.items[key]
+	dispatchAll(evicted, onEvicted)
+}
 
-	var exp int64
-	if c.ttl > 0 {
-		exp = time.Now().Add(c.ttl).UnixNano()
+// Add stores value for key only if the key is not already present, or its existing
+// entry has expired. It returns ErrKeyExists otherwise.
+func (c *Cache[K, V]) Add(key K, value V, ttl time.Duration) error {
+	c.mu.Lock()
+	if el, exists := c.items[key]; exists && !el.Value.(*entry[K, V]).item.expired() {
+		c.mu.Unlock()
+		return ErrKeyExists
 	}
-	c.items[key] = Item[V]{Value: value, Expiration: exp}
-	if !exists {
-		atomic.AddInt64(&c.count, 1)
+	evicted := c.setLocked(key, value, expirationFor(ttl, c.ttl))
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
+	return nil
+}
+
+// Replace updates the value for key only if it is already present and not expired.
+// It returns ErrKeyNotFound otherwise.
+func (c *Cache[K, V]) Replace(key K, value V, ttl time.Duration) error {
+	c.mu.Lock()
+	el, exists := c.items[key]
+	if !exists || el.Value.(*entry[K, V]).item.expired() {
+		c.mu.Unlock()
+		return ErrKeyNotFound
+	}
+	evicted := c.setLocked(key, value, expirationFor(ttl, c.ttl))
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
+	return nil
+}
+
+// GetOrSet returns the existing value for key if present and not expired, together with
+// true. Otherwise it stores value with the given ttl and returns it together with false.
+func (c *Cache[K, V]) GetOrSet(key K, value V, ttl time.Duration) (V, bool) {
+	c.mu.Lock()
+	if el, exists := c.items[key]; exists {
+		en := el.Value.(*entry[K, V])
+		if !en.item.expired() {
+			en.item.Accessed = time.Now().UnixNano()
+			c.ll.MoveToFront(el)
+			v := en.item.Value
+			c.mu.Unlock()
+			atomic.AddUint64(&c.hits, 1)
+			return v, true
+		}
+	}
+	evicted := c.setLocked(key, value, expirationFor(ttl, c.ttl))
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	atomic.AddUint64(&c.misses, 1)
+	dispatchAll(evicted, onEvicted)
+	return value, false
+}
+
+// setLocked inserts key/value with the given absolute expiration, moving it to the
+// front of the LRU list, and evicts the least-recently-used entry if this insert pushed
+// the cache over capacity. It returns any entries evicted as a result (a replaced
+// previous value, or LRU victims), for the caller to dispatch once c.mu is released.
+// Callers must hold c.mu.
+func (c *Cache[K, V]) setLocked(key K, value V, exp int64) []evictedItem[K, V] {
+	atomic.AddUint64(&c.sets, 1)
+	now := time.Now().UnixNano()
+	if el, exists := c.items[key]; exists {
+		en := el.Value.(*entry[K, V])
+		old := en.item.Value
+		en.item = Item[V]{Value: value, Expiration: exp, Accessed: now}
+		c.ll.MoveToFront(el)
+		return []evictedItem[K, V]{{key: key, value: old, reason: EvictionReplaced}}
+	}
+
+	el := c.ll.PushFront(&entry[K, V]{key: key, item: Item[V]{Value: value, Expiration: exp, Accessed: now}})
+	c.items[key] = el
+	atomic.AddInt64(&c.count, 1)
+	return c.evictOverflowLocked()
+}
+
+// evictOverflowLocked evicts least-recently-used entries until the cache is back within
+// capacity, returning what it evicted. It is a no-op for unbounded caches. Callers must
+// hold c.mu.
+func (c *Cache[K, V]) evictOverflowLocked() []evictedItem[K, V] {
+	if c.capacity <= 0 {
+		return nil
+	}
+	var evicted []evictedItem[K, V]
+	for len(c.items) > c.capacity {
+		el := c.ll.Back()
+		if el == nil {
+			break
+		}
+		k, v := c.removeElementLocked(el)
+		evicted = append(evicted, evictedItem[K, V]{key: k, value: v, reason: EvictionCapacity})
+		atomic.AddUint64(&c.evictions, 1)
 	}
+	return evicted
 }
 
-// Get retrieves a value by key. If expired or missing, returns zero value.
+// removeElementLocked unlinks el from the list and map, returning its key and value.
+// It does not close the value or fire OnEvicted; callers must do both, after releasing
+// c.mu, via dispatchEviction or dispatchAll. Callers must hold c.mu.
+func (c *Cache[K, V]) removeElementLocked(el *list.Element) (K, V) {
+	en := el.Value.(*entry[K, V])
+	c.ll.Remove(el)
+	delete(c.items, en.key)
+	atomic.AddInt64(&c.count, -1)
+	return en.key, en.item.Value
+}
+
+// Get retrieves a value by key, marking it most-recently-used. If expired or missing,
+// returns the zero value.
 func (c *Cache[K, V]) Get(key K) (V, bool) {
 	var zero V
 
-	c.mu.RLock()
-	item, found := c.items[key]
-	c.mu.RUnlock()
-
+	c.mu.Lock()
+	el, found := c.items[key]
 	if !found {
+		c.mu.Unlock()
+		atomic.AddUint64(&c.misses, 1)
 		return zero, false
 	}
-	if item.Expiration > 0 && time.Now().UnixNano() > item.Expiration {
-		c.Delete(key)
+
+	en := el.Value.(*entry[K, V])
+	if en.item.expired() {
+		k, v := c.removeElementLocked(el)
+		onEvicted := c.onEvicted
+		c.mu.Unlock()
+
+		atomic.AddUint64(&c.expirations, 1)
+		atomic.AddUint64(&c.misses, 1)
+		dispatchEviction(k, v, EvictionExpired, onEvicted)
+		return zero, false
 	}
-	return item.Value, true
+
+	en.item.Accessed = time.Now().UnixNano()
+	c.ll.MoveToFront(el)
+	value := en.item.Value
+	c.mu.Unlock()
+	atomic.AddUint64(&c.hits, 1)
+	return value, true
 }
 
 // Delete removes a key from the cache.
 func (c *Cache[K, V]) Delete(key K) {
 	c.mu.Lock()
-	if it, exists := c.items[key]; exists {
-		if closable, ok := any(it.Value).(Closable); ok {
-			closable.Close()
+	el, exists := c.items[key]
+	if !exists {
+		c.mu.Unlock()
+		return
+	}
+	k, v := c.removeElementLocked(el)
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	atomic.AddUint64(&c.deletes, 1)
+	dispatchEviction(k, v, EvictionDeleted, onEvicted)
+}
+
+// DeleteLRU evicts up to n of the least-recently-used entries and returns how many
+// were actually removed.
+func (c *Cache[K, V]) DeleteLRU(n int) int {
+	c.mu.Lock()
+	var evicted []evictedItem[K, V]
+	for i := 0; i < n; i++ {
+		el := c.ll.Back()
+		if el == nil {
+			break
 		}
-		delete(c.items, key)
-		atomic.AddInt64(&c.count, -1)
+		k, v := c.removeElementLocked(el)
+		evicted = append(evicted, evictedItem[K, V]{key: k, value: v, reason: EvictionCapacity})
+		atomic.AddUint64(&c.evictions, 1)
 	}
+	onEvicted := c.onEvicted
 	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
+	return len(evicted)
 }
 
 func (c *Cache[K, V]) Len() int {
@@ -85,8 +303,8 @@ func (c *Cache[K, V]) Range(f func(K, V) bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	for k, it := range c.items {
-		if !f(k, it.Value) {
+	for k, el := range c.items {
+		if !f(k, el.Value.(*entry[K, V]).item.Value) {
 			return
 		}
 	}
@@ -100,7 +318,8 @@ func (c *Cache[K, V]) Items() map[K]V {
 	result := make(map[K]V, len(c.items))
 	now := time.Now().UnixNano()
 
-	for k, it := range c.items {
+	for k, el := range c.items {
+		it := el.Value.(*entry[K, V]).item
 		if it.Expiration == 0 || it.Expiration > now {
 			result[k] = it.Value
 		}
@@ -112,16 +331,21 @@ func (c *Cache[K, V]) Items() map[K]V {
 func (c *Cache[K, V]) cleanup() {
 	now := time.Now().UnixNano()
 	c.mu.Lock()
-	for k, v := range c.items {
-		if v.Expiration > 0 && now > v.Expiration {
-			if closable, ok := any(v.Value).(Closable); ok {
-				closable.Close()
-			}
-			delete(c.items, k)
-			atomic.AddInt64(&c.count, -1)
+	var evicted []evictedItem[K, V]
+	for el := c.ll.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.(*entry[K, V]).item.Expiration > 0 && now > el.Value.(*entry[K, V]).item.Expiration {
+			k, v := c.removeElementLocked(el)
+			evicted = append(evicted, evictedItem[K, V]{key: k, value: v, reason: EvictionExpired})
+			atomic.AddUint64(&c.expirations, 1)
 		}
+		el = next
 	}
+	evicted = append(evicted, c.evictOverflowLocked()...)
+	onEvicted := c.onEvicted
 	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
 }
 
 func (c *Cache[K, V]) Close() {
@@ -132,7 +356,16 @@ func (c *Cache[K, V]) Close() {
 
 func (c *Cache[K, V]) Clear() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.items = make(map[K]Item[V])
+	var evicted []evictedItem[K, V]
+	for _, el := range c.items {
+		en := el.Value.(*entry[K, V])
+		evicted = append(evicted, evictedItem[K, V]{key: en.key, value: en.item.Value, reason: EvictionCleared})
+	}
+	onEvicted := c.onEvicted
+	c.items = make(map[K]*list.Element)
+	c.ll = list.New()
 	c.count = 0
+	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
 }
@@ -44,6 +44,260 @@ func TestExpiration(t *testing.T) {
 	}
 }
 
+func TestSetWithTTLOverridesDefault(t *testing.T) {
+	c := New[string, string](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.SetWithTTL("short", "value", 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	if _, ok := c.Get("short"); ok {
+		t.Errorf("expected key to expire based on its own TTL, not the cache default")
+	}
+}
+
+func TestSetWithTTLNoExpiration(t *testing.T) {
+	c := New[string, string](50*time.Millisecond, time.Millisecond*10)
+	defer c.Close()
+
+	c.SetWithTTL("forever", "value", NoExpiration)
+	time.Sleep(100 * time.Millisecond)
+
+	if v, ok := c.Get("forever"); !ok || v != "value" {
+		t.Errorf("expected key with NoExpiration to survive, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	if err := c.Add("k", 1, DefaultExpiration); err != nil {
+		t.Fatalf("expected first Add to succeed, got %v", err)
+	}
+	if err := c.Add("k", 2, DefaultExpiration); err != ErrKeyExists {
+		t.Fatalf("expected ErrKeyExists, got %v", err)
+	}
+	if v, _ := c.Get("k"); v != 1 {
+		t.Errorf("expected value to remain 1, got %v", v)
+	}
+}
+
+func TestAddAfterExpiration(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.SetWithTTL("k", 1, 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	if err := c.Add("k", 2, DefaultExpiration); err != nil {
+		t.Fatalf("expected Add to succeed over an expired entry, got %v", err)
+	}
+	if v, _ := c.Get("k"); v != 2 {
+		t.Errorf("expected value 2, got %v", v)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	if err := c.Replace("missing", 1, DefaultExpiration); err != ErrKeyNotFound {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	c.Set("k", 1)
+	if err := c.Replace("k", 2, DefaultExpiration); err != nil {
+		t.Fatalf("expected Replace to succeed, got %v", err)
+	}
+	if v, _ := c.Get("k"); v != 2 {
+		t.Errorf("expected value 2, got %v", v)
+	}
+}
+
+func TestGetOrSet(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	v, found := c.GetOrSet("k", 1, DefaultExpiration)
+	if found || v != 1 {
+		t.Fatalf("expected (1, false) on first call, got (%v, %v)", v, found)
+	}
+
+	v, found = c.GetOrSet("k", 2, DefaultExpiration)
+	if !found || v != 1 {
+		t.Fatalf("expected (1, true) on second call, got (%v, %v)", v, found)
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewWithCapacity[string, int](2, time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // touch a, making b the LRU entry
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("expected b to be evicted as least-recently-used")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("expected a to survive eviction")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Errorf("expected c to survive eviction")
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected length 2, got %d", c.Len())
+	}
+}
+
+type closeRecorder struct{ closed *bool }
+
+func (r closeRecorder) Close() { *r.closed = true }
+
+func TestLRUEvictionClosesValue(t *testing.T) {
+	c := NewWithCapacity[string, closeRecorder](1, time.Minute, time.Second*10)
+	defer c.Close()
+
+	closed := false
+	c.Set("a", closeRecorder{closed: &closed})
+	c.Set("b", closeRecorder{closed: new(bool)}) // forces eviction of "a"
+
+	if !closed {
+		t.Errorf("expected evicted value's Close to be called")
+	}
+}
+
+func TestDeleteLRU(t *testing.T) {
+	c := NewWithCapacity[string, int](10, time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	if n := c.DeleteLRU(2); n != 2 {
+		t.Fatalf("expected to remove 2 items, removed %d", n)
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Errorf("expected most-recently-used entry c to survive")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("expected length 1, got %d", c.Len())
+	}
+}
+
+func TestOnEvictedDelete(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	var gotKey string
+	var gotValue int
+	var gotReason EvictionReason
+	c.OnEvicted(func(k string, v int, r EvictionReason) {
+		gotKey, gotValue, gotReason = k, v, r
+	})
+
+	c.Set("a", 1)
+	c.Delete("a")
+
+	if gotKey != "a" || gotValue != 1 || gotReason != EvictionDeleted {
+		t.Errorf("expected (a, 1, deleted), got (%v, %v, %v)", gotKey, gotValue, gotReason)
+	}
+}
+
+func TestOnEvictedReplaced(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	var gotReason EvictionReason
+	var gotValue int
+	c.OnEvicted(func(k string, v int, r EvictionReason) {
+		gotValue, gotReason = v, r
+	})
+
+	c.Set("a", 1)
+	c.Set("a", 2)
+
+	if gotValue != 1 || gotReason != EvictionReplaced {
+		t.Errorf("expected (1, replaced), got (%v, %v)", gotValue, gotReason)
+	}
+}
+
+func TestOnEvictedExpired(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	var gotReason EvictionReason
+	c.OnEvicted(func(k string, v int, r EvictionReason) {
+		gotReason = r
+	})
+
+	c.SetWithTTL("a", 1, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	c.Get("a")
+
+	if gotReason != EvictionExpired {
+		t.Errorf("expected expired reason, got %v", gotReason)
+	}
+}
+
+func TestOnEvictedCapacity(t *testing.T) {
+	c := NewWithCapacity[string, int](1, time.Minute, time.Second*10)
+	defer c.Close()
+
+	var gotKey string
+	var gotReason EvictionReason
+	c.OnEvicted(func(k string, v int, r EvictionReason) {
+		gotKey, gotReason = k, r
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if gotKey != "a" || gotReason != EvictionCapacity {
+		t.Errorf("expected (a, capacity), got (%v, %v)", gotKey, gotReason)
+	}
+}
+
+func TestOnEvictedCleared(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	reasons := make(map[string]EvictionReason)
+	c.OnEvicted(func(k string, v int, r EvictionReason) {
+		reasons[k] = r
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Clear()
+
+	if reasons["a"] != EvictionCleared || reasons["b"] != EvictionCleared {
+		t.Errorf("expected both keys cleared, got %v", reasons)
+	}
+}
+
+func TestOnEvictedClosesValueFirst(t *testing.T) {
+	c := New[string, closeRecorder](time.Minute, time.Second*10)
+	defer c.Close()
+
+	closed := false
+	var closedDuringCallback bool
+	c.OnEvicted(func(k string, v closeRecorder, r EvictionReason) {
+		closedDuringCallback = closed
+	})
+
+	c.Set("a", closeRecorder{closed: &closed})
+	c.Delete("a")
+
+	if !closedDuringCallback {
+		t.Errorf("expected value to be Closed before OnEvicted runs")
+	}
+}
+
 func TestCacheLen(t *testing.T) {
 	c := New[string, int](time.Minute, time.Minute)
 
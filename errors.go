@@ -0,0 +1,11 @@
+package cachex
+
+import "errors"
+
+var (
+	// ErrKeyExists is returned by Add when the key is already present and not expired.
+	ErrKeyExists = errors.New("cachex: key already exists")
+
+	// ErrKeyNotFound is returned by Replace when the key does not exist or has expired.
+	ErrKeyNotFound = errors.New("cachex: key not found")
+)
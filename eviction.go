@@ -0,0 +1,65 @@
+package cachex
+
+// EvictionReason describes why an entry left the cache, passed to an OnEvicted callback.
+type EvictionReason int
+
+const (
+	// EvictionDeleted means the entry was removed by an explicit Delete call.
+	EvictionDeleted EvictionReason = iota
+	// EvictionExpired means the entry's TTL had passed when it was found or swept by
+	// the janitor.
+	EvictionExpired
+	// EvictionReplaced means a Set-like call overwrote the entry with a new value.
+	EvictionReplaced
+	// EvictionCapacity means the entry was the least-recently-used victim of a
+	// capacity-bounded cache, evicted by a Set-like call, DeleteLRU, Load, or the
+	// janitor.
+	EvictionCapacity
+	// EvictionCleared means the entry was removed by a Clear call.
+	EvictionCleared
+)
+
+// String returns a lower-case name for the reason, mainly useful for logging.
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictionDeleted:
+		return "deleted"
+	case EvictionExpired:
+		return "expired"
+	case EvictionReplaced:
+		return "replaced"
+	case EvictionCapacity:
+		return "capacity"
+	case EvictionCleared:
+		return "cleared"
+	default:
+		return "unknown"
+	}
+}
+
+// evictedItem records a key/value pair removed from the cache, pending dispatch to
+// Close and the user's OnEvicted callback outside the cache's lock.
+type evictedItem[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// dispatchEviction closes value if it implements Closable, then invokes onEvicted if
+// set. It must be called without holding the cache's lock, since onEvicted may re-enter
+// the cache.
+func dispatchEviction[K comparable, V any](key K, value V, reason EvictionReason, onEvicted func(K, V, EvictionReason)) {
+	if closable, ok := any(value).(Closable); ok {
+		closable.Close()
+	}
+	if onEvicted != nil {
+		onEvicted(key, value, reason)
+	}
+}
+
+// dispatchAll calls dispatchEviction for each evicted item, in order.
+func dispatchAll[K comparable, V any](evicted []evictedItem[K, V], onEvicted func(K, V, EvictionReason)) {
+	for _, e := range evicted {
+		dispatchEviction(e.key, e.value, e.reason, onEvicted)
+	}
+}
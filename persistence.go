@@ -0,0 +1,117 @@
+package cachex
+
+import (
+	"container/list"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// NewFrom creates a cache pre-populated with items, typically restored from a prior
+// Save/Load round-trip. Entries already expired relative to time.Now are dropped.
+func NewFrom[K comparable, V any](defaultTTL, cleanupInterval time.Duration, items map[K]Item[V]) *Cache[K, V] {
+	c := newCache[K, V](defaultTTL, cleanupInterval, 0)
+	c.restore(items)
+	return c
+}
+
+// snapshot returns a copy of the cache's items, suitable for gob encoding.
+func (c *Cache[K, V]) snapshot() map[K]Item[V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m := make(map[K]Item[V], len(c.items))
+	for k, el := range c.items {
+		m[k] = el.Value.(*entry[K, V]).item
+	}
+	return m
+}
+
+// restore replaces the cache's contents with snapshot, dropping entries whose absolute
+// Expiration has already passed and keeping the rest with their original expiration.
+func (c *Cache[K, V]) restore(snapshot map[K]Item[V]) {
+	now := time.Now().UnixNano()
+
+	type kv struct {
+		key  K
+		item Item[V]
+	}
+	live := make([]kv, 0, len(snapshot))
+	for k, it := range snapshot {
+		if it.Expiration > 0 && now > it.Expiration {
+			continue
+		}
+		live = append(live, kv{key: k, item: it})
+	}
+	// Oldest Accessed first, so the most-recently-accessed entry ends up at the
+	// front of the list once pushed, preserving LRU order across a restore.
+	sort.Slice(live, func(i, j int) bool {
+		return live[i].item.Accessed < live[j].item.Accessed
+	})
+
+	c.mu.Lock()
+
+	c.items = make(map[K]*list.Element, len(live))
+	c.ll = list.New()
+	atomic.StoreInt64(&c.count, 0)
+
+	for _, e := range live {
+		el := c.ll.PushFront(&entry[K, V]{key: e.key, item: e.item})
+		c.items[e.key] = el
+		atomic.AddInt64(&c.count, 1)
+	}
+	evicted := c.evictOverflowLocked()
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	dispatchAll(evicted, onEvicted)
+}
+
+// Save gob-encodes the cache's contents to w, including each entry's absolute
+// Expiration so that remaining TTLs survive a restart. V must be registered with
+// gob.Register if it is an interface type or is stored behind one.
+func (c *Cache[K, V]) Save(w io.Writer) error {
+	if err := gob.NewEncoder(w).Encode(c.snapshot()); err != nil {
+		return fmt.Errorf("cachex: encode cache: %w", err)
+	}
+	return nil
+}
+
+// SaveFile gob-encodes the cache's contents to the file at path, creating or
+// truncating it as needed.
+func (c *Cache[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cachex: create %s: %w", path, err)
+	}
+	defer f.Close()
+	return c.Save(f)
+}
+
+// Load replaces the cache's contents with a gob-encoded snapshot read from r. Entries
+// that have already expired are dropped. The previous contents are discarded without
+// calling Close or OnEvicted on them. V must be registered with gob.Register if it
+// is an interface type or is stored behind one.
+func (c *Cache[K, V]) Load(r io.Reader) error {
+	var snapshot map[K]Item[V]
+	if err := gob.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("cachex: decode cache: %w", err)
+	}
+	c.restore(snapshot)
+	return nil
+}
+
+// LoadFile replaces the cache's contents with a gob-encoded snapshot read from the
+// file at path.
+func (c *Cache[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cachex: open %s: %w", path, err)
+	}
+	defer f.Close()
+	return c.Load(f)
+}
@@ -0,0 +1,249 @@
+package cachex
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoad(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, NoExpiration)
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := New[string, int](time.Minute, time.Second*10)
+	defer restored.Close()
+
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if v, ok := restored.Get("a"); !ok || v != 1 {
+		t.Errorf("expected a=1, got %v (ok=%v)", v, ok)
+	}
+	if v, ok := restored.Get("b"); !ok || v != 2 {
+		t.Errorf("expected b=2, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestSaveLoadDropsExpiredEntries(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.SetWithTTL("expired", 1, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := New[string, int](time.Minute, time.Second*10)
+	defer restored.Close()
+
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, ok := restored.Get("expired"); ok {
+		t.Errorf("expected already-expired entry to be dropped on load")
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+	c.Set("a", 1)
+
+	path := filepath.Join(t.TempDir(), "cache.gob")
+	if err := c.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	restored := New[string, int](time.Minute, time.Second*10)
+	defer restored.Close()
+	if err := restored.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if v, ok := restored.Get("a"); !ok || v != 1 {
+		t.Errorf("expected a=1, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestLoadFileMissing(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	if err := c.LoadFile(filepath.Join(t.TempDir(), "missing.gob")); err == nil {
+		t.Errorf("expected error loading a missing file")
+	}
+}
+
+func TestNewFrom(t *testing.T) {
+	now := time.Now().UnixNano()
+	items := map[string]Item[int]{
+		"live":    {Value: 1, Expiration: 0},
+		"expired": {Value: 2, Expiration: now - int64(time.Second)},
+	}
+
+	c := NewFrom[string, int](time.Minute, time.Second*10, items)
+	defer c.Close()
+
+	if v, ok := c.Get("live"); !ok || v != 1 {
+		t.Errorf("expected live=1, got %v (ok=%v)", v, ok)
+	}
+	if _, ok := c.Get("expired"); ok {
+		t.Errorf("expected expired entry to be dropped by NewFrom")
+	}
+}
+
+func TestShardedSaveLoad(t *testing.T) {
+	sc := NewSharded[string, int](4, time.Minute, time.Second*10)
+	for i := 0; i < 20; i++ {
+		sc.Set(string(rune('a'+i)), i)
+	}
+
+	var buf bytes.Buffer
+	if err := sc.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewSharded[string, int](4, time.Minute, time.Second*10)
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if restored.Len() != sc.Len() {
+		t.Fatalf("expected length %d, got %d", sc.Len(), restored.Len())
+	}
+}
+
+func TestLoadOverCapacityFiresOnEvicted(t *testing.T) {
+	c := NewWithCapacity[string, int](1, time.Minute, time.Second*10)
+	defer c.Close()
+
+	var evictedKeys []string
+	c.OnEvicted(func(k string, v int, reason EvictionReason) {
+		evictedKeys = append(evictedKeys, k)
+	})
+
+	items := map[string]Item[int]{
+		"a": {Value: 1, Accessed: 1},
+		"b": {Value: 2, Accessed: 2},
+		"c": {Value: 3, Accessed: 3},
+	}
+	c.restore(items)
+
+	if c.Len() != 1 {
+		t.Fatalf("expected capacity-bounded restore to keep 1 entry, got %d", c.Len())
+	}
+	if len(evictedKeys) != 2 {
+		t.Errorf("expected OnEvicted to fire for the 2 capacity victims, got %d", len(evictedKeys))
+	}
+}
+
+func TestLoadPreservesLRUOrder(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	for i := 0; i < 10; i++ {
+		c.Set(string(rune('a'+i)), i)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewWithCapacity[string, int](1, time.Minute, time.Second*10)
+	defer restored.Close()
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if v, ok := restored.Get("j"); !ok || v != 9 {
+		t.Errorf("expected the most-recently-accessed entry 'j' to survive restore into a capacity-1 cache, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestLoadPreservesLRUOrderAfterGetOrSetHit(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("k0", 0)
+	c.Set("k1", 1)
+	c.Set("k2", 2)
+
+	// Repeated GetOrSet hits should keep k0 warm as the most-recently-used entry,
+	// even though it was the first key set.
+	for i := 0; i < 3; i++ {
+		c.GetOrSet("k0", 0, DefaultExpiration)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewWithCapacity[string, int](1, time.Minute, time.Second*10)
+	defer restored.Close()
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if v, ok := restored.Get("k0"); !ok || v != 0 {
+		t.Errorf("expected k0 (kept warm via GetOrSet hits) to survive restore into a capacity-1 cache, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestLoadPreservesLRUOrderAfterGetOrComputeHit(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("k0", 0)
+	c.Set("k1", 1)
+	c.Set("k2", 2)
+
+	loader := func(string) (int, error) { return 0, nil }
+	// Repeated GetOrCompute hits should keep k0 warm as the most-recently-used entry.
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetOrCompute("k0", DefaultExpiration, loader); err != nil {
+			t.Fatalf("GetOrCompute failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewWithCapacity[string, int](1, time.Minute, time.Second*10)
+	defer restored.Close()
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if v, ok := restored.Get("k0"); !ok || v != 0 {
+		t.Errorf("expected k0 (kept warm via GetOrCompute hits) to survive restore into a capacity-1 cache, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestShardedLoadShardCountMismatch(t *testing.T) {
+	sc := NewSharded[string, int](4, time.Minute, time.Second*10)
+	sc.Set("a", 1)
+
+	var buf bytes.Buffer
+	if err := sc.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewSharded[string, int](8, time.Minute, time.Second*10)
+	if err := restored.Load(&buf); err == nil {
+		t.Errorf("expected error loading a snapshot with a different shard count")
+	}
+}
@@ -1,9 +1,12 @@
 package cachex
 
 import (
+	"encoding/gob"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"maps"
+	"os"
 	"time"
 )
 
@@ -28,6 +31,30 @@ func NewSharded[K comparable, V any](numShards int, defaultTTL, cleanupInterval
 	return sc
 }
 
+// NewShardedWithCapacity creates a sharded cache bounded to maxItems entries in total,
+// split evenly across shards (each shard gets at least 1).
+func NewShardedWithCapacity[K comparable, V any](numShards, maxItems int, defaultTTL, cleanupInterval time.Duration) *ShardedCache[K, V] {
+	perShard := maxItems / numShards
+	if perShard < 1 {
+		perShard = 1
+	}
+	sc := &ShardedCache[K, V]{
+		shards: make([]*Cache[K, V], numShards),
+	}
+	for i := range numShards {
+		sc.shards[i] = NewWithCapacity[K, V](perShard, defaultTTL, cleanupInterval)
+	}
+	return sc
+}
+
+// OnEvicted registers fn on every shard to be called whenever an entry leaves the
+// cache, reporting why.
+func (sc *ShardedCache[K, V]) OnEvicted(fn func(K, V, EvictionReason)) {
+	for _, shard := range sc.shards {
+		shard.OnEvicted(fn)
+	}
+}
+
 func (sc *ShardedCache[K, V]) Set(key K, value V) {
 	idx := hashKey(key, len(sc.shards))
 	sc.shards[idx].Set(key, value)
@@ -43,6 +70,144 @@ func (sc *ShardedCache[K, V]) Delete(key K) {
 	sc.shards[idx].Delete(key)
 }
 
+// SetWithTTL stores a key-value pair with a per-entry TTL overriding the shard's default.
+// Pass NoExpiration for an entry that should never expire, or DefaultExpiration to fall
+// back to the cache's default TTL.
+func (sc *ShardedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	idx := hashKey(key, len(sc.shards))
+	sc.shards[idx].SetWithTTL(key, value, ttl)
+}
+
+// Add stores value for key only if the key is not already present, or its existing
+// entry has expired. It returns ErrKeyExists otherwise.
+func (sc *ShardedCache[K, V]) Add(key K, value V, ttl time.Duration) error {
+	idx := hashKey(key, len(sc.shards))
+	return sc.shards[idx].Add(key, value, ttl)
+}
+
+// Replace updates the value for key only if it is already present and not expired.
+// It returns ErrKeyNotFound otherwise.
+func (sc *ShardedCache[K, V]) Replace(key K, value V, ttl time.Duration) error {
+	idx := hashKey(key, len(sc.shards))
+	return sc.shards[idx].Replace(key, value, ttl)
+}
+
+// GetOrSet returns the existing value for key if present and not expired, together with
+// true. Otherwise it stores value with the given ttl and returns it together with false.
+func (sc *ShardedCache[K, V]) GetOrSet(key K, value V, ttl time.Duration) (V, bool) {
+	idx := hashKey(key, len(sc.shards))
+	return sc.shards[idx].GetOrSet(key, value, ttl)
+}
+
+// DeleteLRU evicts up to n entries in total across all shards, and returns the total
+// number of entries removed. Shards are drained round-robin, taking each shard's
+// least-recently-used entry one at a time, so the victims are spread evenly rather
+// than all coming from the first shard; they are not necessarily the n
+// least-recently-used entries of the cache as a whole.
+func (sc *ShardedCache[K, V]) DeleteLRU(n int) int {
+	total := 0
+	for total < n {
+		removedThisRound := 0
+		for _, shard := range sc.shards {
+			if total >= n {
+				break
+			}
+			removed := shard.DeleteLRU(1)
+			total += removed
+			removedThisRound += removed
+		}
+		if removedThisRound == 0 {
+			break // every shard is empty
+		}
+	}
+	return total
+}
+
+// Save gob-encodes the contents of every shard to w, including each entry's absolute
+// Expiration so that remaining TTLs survive a restart. V must be registered with
+// gob.Register if it is an interface type or is stored behind one.
+func (sc *ShardedCache[K, V]) Save(w io.Writer) error {
+	snapshots := make([]map[K]Item[V], len(sc.shards))
+	for i, shard := range sc.shards {
+		snapshots[i] = shard.snapshot()
+	}
+	if err := gob.NewEncoder(w).Encode(snapshots); err != nil {
+		return fmt.Errorf("cachex: encode sharded cache: %w", err)
+	}
+	return nil
+}
+
+// SaveFile gob-encodes the contents of every shard to the file at path, creating or
+// truncating it as needed.
+func (sc *ShardedCache[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cachex: create %s: %w", path, err)
+	}
+	defer f.Close()
+	return sc.Save(f)
+}
+
+// Load replaces the contents of every shard with a gob-encoded snapshot read from r,
+// which must have been produced by Save from a ShardedCache with the same number of
+// shards. Entries that have already expired are dropped. The previous contents are
+// discarded without calling Close or OnEvicted on them.
+func (sc *ShardedCache[K, V]) Load(r io.Reader) error {
+	var snapshots []map[K]Item[V]
+	if err := gob.NewDecoder(r).Decode(&snapshots); err != nil {
+		return fmt.Errorf("cachex: decode sharded cache: %w", err)
+	}
+	if len(snapshots) != len(sc.shards) {
+		return fmt.Errorf("cachex: snapshot has %d shards, cache has %d", len(snapshots), len(sc.shards))
+	}
+	for i, shard := range sc.shards {
+		shard.restore(snapshots[i])
+	}
+	return nil
+}
+
+// LoadFile replaces the contents of every shard with a gob-encoded snapshot read from
+// the file at path.
+func (sc *ShardedCache[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cachex: open %s: %w", path, err)
+	}
+	defer f.Close()
+	return sc.Load(f)
+}
+
+// GetOrCompute returns the cached value for key if present and not expired, otherwise
+// calls loader to produce one and stores it with ttl. Concurrent callers racing on the
+// same missing key within a shard share a single loader invocation.
+func (sc *ShardedCache[K, V]) GetOrCompute(key K, ttl time.Duration, loader func(K) (V, error)) (V, error) {
+	idx := hashKey(key, len(sc.shards))
+	return sc.shards[idx].GetOrCompute(key, ttl, loader)
+}
+
+// Stats returns the sum of every shard's usage counters.
+func (sc *ShardedCache[K, V]) Stats() Stats {
+	var total Stats
+	for _, shard := range sc.shards {
+		s := shard.Stats()
+		total.Hits += s.Hits
+		total.Misses += s.Misses
+		total.Evictions += s.Evictions
+		total.Expirations += s.Expirations
+		total.Sets += s.Sets
+		total.Deletes += s.Deletes
+		total.CurrentSize += s.CurrentSize
+	}
+	return total
+}
+
+// ResetStats zeroes the usage counters of every shard.
+func (sc *ShardedCache[K, V]) ResetStats() {
+	for _, shard := range sc.shards {
+		shard.ResetStats()
+	}
+}
+
 // Items returns a copy of all key-value pairs across all shards.
 func (sc *ShardedCache[K, V]) Items() map[K]V {
 	result := make(map[K]V)
@@ -24,6 +24,79 @@ func TestShardedCacheBasic(t *testing.T) {
 	}
 }
 
+func TestShardedCacheAddReplaceGetOrSet(t *testing.T) {
+	sc := NewSharded[string, int](8, time.Minute, time.Second*10)
+
+	if err := sc.Add("k", 1, DefaultExpiration); err != nil {
+		t.Fatalf("expected Add to succeed, got %v", err)
+	}
+	if err := sc.Add("k", 2, DefaultExpiration); err != ErrKeyExists {
+		t.Fatalf("expected ErrKeyExists, got %v", err)
+	}
+
+	if err := sc.Replace("k", 3, DefaultExpiration); err != nil {
+		t.Fatalf("expected Replace to succeed, got %v", err)
+	}
+	if v, _ := sc.Get("k"); v != 3 {
+		t.Errorf("expected value 3, got %v", v)
+	}
+
+	v, found := sc.GetOrSet("other", 9, DefaultExpiration)
+	if found || v != 9 {
+		t.Fatalf("expected (9, false), got (%v, %v)", v, found)
+	}
+}
+
+func TestShardedCacheWithCapacityEvicts(t *testing.T) {
+	sc := NewShardedWithCapacity[string, int](1, 2, time.Minute, time.Second*10)
+
+	sc.Set("a", 1)
+	sc.Set("b", 2)
+	sc.Set("c", 3) // single shard, capacity 2: evicts "a"
+
+	if _, ok := sc.Get("a"); ok {
+		t.Errorf("expected a to be evicted as least-recently-used")
+	}
+	if sc.Len() != 2 {
+		t.Fatalf("expected length 2, got %d", sc.Len())
+	}
+}
+
+func TestShardedCacheOnEvicted(t *testing.T) {
+	sc := NewSharded[string, int](8, time.Minute, time.Second*10)
+
+	var mu sync.Mutex
+	reasons := make(map[string]EvictionReason)
+	sc.OnEvicted(func(k string, v int, r EvictionReason) {
+		mu.Lock()
+		reasons[k] = r
+		mu.Unlock()
+	})
+
+	sc.Set("a", 1)
+	sc.Delete("a")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if reasons["a"] != EvictionDeleted {
+		t.Errorf("expected a to be evicted with reason deleted, got %v", reasons["a"])
+	}
+}
+
+func TestShardedCacheDeleteLRUTotalAcrossShards(t *testing.T) {
+	sc := NewSharded[string, int](4, time.Minute, time.Second*10)
+	for i := 0; i < 20; i++ {
+		sc.Set(fmt.Sprintf("k%d", i), i)
+	}
+
+	if n := sc.DeleteLRU(5); n != 5 {
+		t.Fatalf("expected DeleteLRU(5) to remove 5 entries total, got %d", n)
+	}
+	if sc.Len() != 15 {
+		t.Errorf("expected 15 entries remaining, got %d", sc.Len())
+	}
+}
+
 func TestShardedCacheConcurrentReadWrite(t *testing.T) {
 	const workers = 50
 	const iterations = 5000
@@ -0,0 +1,71 @@
+package cachex
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// call represents an in-flight GetOrCompute load for a single key: waiters block on wg
+// until the loader finishes, then read val/err.
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// GetOrCompute returns the cached value for key if present and not expired. Otherwise
+// it calls loader to produce one, storing the result with ttl (or the cache's default
+// TTL when ttl is DefaultExpiration) on success. Concurrent callers racing on the same
+// missing key share a single loader invocation instead of each calling loader
+// themselves; an error from loader is returned to every waiter but never cached.
+func (c *Cache[K, V]) GetOrCompute(key K, ttl time.Duration, loader func(K) (V, error)) (V, error) {
+	c.mu.Lock()
+	if el, exists := c.items[key]; exists {
+		en := el.Value.(*entry[K, V])
+		if !en.item.expired() {
+			en.item.Accessed = time.Now().UnixNano()
+			c.ll.MoveToFront(el)
+			v := en.item.Value
+			c.mu.Unlock()
+			atomic.AddUint64(&c.hits, 1)
+			return v, nil
+		}
+	}
+
+	if cl, inFlight := c.inflight[key]; inFlight {
+		c.mu.Unlock()
+		atomic.AddUint64(&c.misses, 1)
+		cl.wg.Wait()
+		return cl.val, cl.err
+	}
+
+	cl := &call[V]{}
+	cl.wg.Add(1)
+	if c.inflight == nil {
+		c.inflight = make(map[K]*call[V])
+	}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+	atomic.AddUint64(&c.misses, 1)
+
+	defer func() {
+		cl.wg.Done()
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+	}()
+
+	cl.val, cl.err = loader(key)
+	if cl.err != nil {
+		return cl.val, cl.err
+	}
+
+	c.mu.Lock()
+	evicted := c.setLocked(key, cl.val, expirationFor(ttl, c.ttl))
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+	dispatchAll(evicted, onEvicted)
+
+	return cl.val, nil
+}
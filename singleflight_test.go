@@ -0,0 +1,87 @@
+package cachex
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrComputeCacheHit(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("k", 42)
+
+	v, err := c.GetOrCompute("k", DefaultExpiration, func(string) (int, error) {
+		t.Fatal("loader should not be called on a cache hit")
+		return 0, nil
+	})
+	if err != nil || v != 42 {
+		t.Fatalf("expected (42, nil), got (%v, %v)", v, err)
+	}
+}
+
+func TestGetOrComputeSingleflight(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	var calls int32
+	const workers = 50
+
+	var wg sync.WaitGroup
+	results := make([]int, workers)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrCompute("k", DefaultExpiration, func(string) (int, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return 7, nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected loader to run exactly once, ran %d times", got)
+	}
+	for i, v := range results {
+		if v != 7 {
+			t.Errorf("worker %d got %d, want 7", i, v)
+		}
+	}
+	if v, ok := c.Get("k"); !ok || v != 7 {
+		t.Errorf("expected value to be cached after load, got %v (ok=%v)", v, ok)
+	}
+}
+
+func TestGetOrComputeErrorNotCached(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	loadErr := errors.New("boom")
+	_, err := c.GetOrCompute("k", DefaultExpiration, func(string) (int, error) {
+		return 0, loadErr
+	})
+	if !errors.Is(err, loadErr) {
+		t.Fatalf("expected loader error, got %v", err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Errorf("expected failed load to not be cached")
+	}
+
+	// A subsequent call should retry the loader rather than being stuck in-flight.
+	v, err := c.GetOrCompute("k", DefaultExpiration, func(string) (int, error) {
+		return 9, nil
+	})
+	if err != nil || v != 9 {
+		t.Fatalf("expected retry to succeed with (9, nil), got (%v, %v)", v, err)
+	}
+}
@@ -0,0 +1,46 @@
+package cachex
+
+import "sync/atomic"
+
+// Stats is a point-in-time snapshot of a cache's usage counters.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Sets        uint64
+	Deletes     uint64
+	CurrentSize int
+}
+
+// HitRatio returns Hits / (Hits + Misses), or 0 if there have been no lookups yet.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Stats returns a snapshot of the cache's usage counters.
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:        atomic.LoadUint64(&c.hits),
+		Misses:      atomic.LoadUint64(&c.misses),
+		Evictions:   atomic.LoadUint64(&c.evictions),
+		Expirations: atomic.LoadUint64(&c.expirations),
+		Sets:        atomic.LoadUint64(&c.sets),
+		Deletes:     atomic.LoadUint64(&c.deletes),
+		CurrentSize: c.Len(),
+	}
+}
+
+// ResetStats zeroes the cache's usage counters.
+func (c *Cache[K, V]) ResetStats() {
+	atomic.StoreUint64(&c.hits, 0)
+	atomic.StoreUint64(&c.misses, 0)
+	atomic.StoreUint64(&c.evictions, 0)
+	atomic.StoreUint64(&c.expirations, 0)
+	atomic.StoreUint64(&c.sets, 0)
+	atomic.StoreUint64(&c.deletes, 0)
+}
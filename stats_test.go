@@ -0,0 +1,119 @@
+package cachex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsHitsAndMisses(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("a")
+	c.Get("missing")
+
+	s := c.Stats()
+	if s.Hits != 2 {
+		t.Errorf("expected 2 hits, got %d", s.Hits)
+	}
+	if s.Misses != 1 {
+		t.Errorf("expected 1 miss, got %d", s.Misses)
+	}
+	if s.Sets != 1 {
+		t.Errorf("expected 1 set, got %d", s.Sets)
+	}
+	if s.CurrentSize != 1 {
+		t.Errorf("expected current size 1, got %d", s.CurrentSize)
+	}
+	if got, want := s.HitRatio(), 2.0/3.0; got != want {
+		t.Errorf("expected hit ratio %v, got %v", want, got)
+	}
+}
+
+func TestStatsDeletesAndExpirations(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Delete("a")
+
+	c.SetWithTTL("b", 2, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	c.Get("b")
+
+	s := c.Stats()
+	if s.Deletes != 1 {
+		t.Errorf("expected 1 delete, got %d", s.Deletes)
+	}
+	if s.Expirations != 1 {
+		t.Errorf("expected 1 expiration, got %d", s.Expirations)
+	}
+}
+
+func TestStatsEvictions(t *testing.T) {
+	c := NewWithCapacity[string, int](1, time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2) // evicts a
+
+	if s := c.Stats(); s.Evictions != 1 {
+		t.Errorf("expected 1 eviction, got %d", s.Evictions)
+	}
+}
+
+func TestResetStats(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Get("a")
+	c.ResetStats()
+
+	s := c.Stats()
+	if s.Hits != 0 || s.Sets != 0 {
+		t.Errorf("expected stats to be reset, got %+v", s)
+	}
+}
+
+func TestStatsGetOrSetAndGetOrCompute(t *testing.T) {
+	c := New[string, int](time.Minute, time.Second*10)
+	defer c.Close()
+
+	c.GetOrSet("a", 1, DefaultExpiration) // miss
+	c.GetOrSet("a", 2, DefaultExpiration) // hit
+
+	_, _ = c.GetOrCompute("b", DefaultExpiration, func(string) (int, error) { return 9, nil }) // miss
+	_, _ = c.GetOrCompute("b", DefaultExpiration, func(string) (int, error) { return 9, nil }) // hit
+
+	s := c.Stats()
+	if s.Hits != 2 {
+		t.Errorf("expected 2 hits, got %d", s.Hits)
+	}
+	if s.Misses != 2 {
+		t.Errorf("expected 2 misses, got %d", s.Misses)
+	}
+}
+
+func TestShardedStats(t *testing.T) {
+	sc := NewSharded[string, int](4, time.Minute, time.Second*10)
+
+	sc.Set("a", 1)
+	sc.Get("a")
+	sc.Get("missing")
+
+	s := sc.Stats()
+	if s.Hits != 1 || s.Misses != 1 || s.Sets != 1 {
+		t.Errorf("expected hits=1 misses=1 sets=1, got %+v", s)
+	}
+	if s.CurrentSize != 1 {
+		t.Errorf("expected current size 1, got %d", s.CurrentSize)
+	}
+
+	sc.ResetStats()
+	if s := sc.Stats(); s.Hits != 0 {
+		t.Errorf("expected stats reset across shards, got %+v", s)
+	}
+}
@@ -6,6 +6,12 @@ import "time"
 type Item[V any] struct {
 	Value      V
 	Expiration int64 // 0 means never expires
+	Accessed   int64 // UnixNano of the last Get/Set; used for LRU eviction
+}
+
+// expired reports whether the item has an expiration set and it has passed.
+func (it Item[V]) expired() bool {
+	return it.Expiration > 0 && time.Now().UnixNano() > it.Expiration
 }
 
 type janitor struct {